@@ -9,36 +9,116 @@ import (
 // Hash represents the hashing algorithm used.
 type Hash func(data []byte) uint32
 
+// defaultReplicas is used when WithReplicas is omitted.
+const defaultReplicas = 50
+
 type Map struct {
-	hash     Hash
-	replicas int
-	keys     []int // sorted
-	hashMap  map[int]string
+	hash         Hash
+	replicas     int
+	weightScaler func(weight int) int
+	keys         []int // sorted
+	hashMap      map[int]string
+
+	// nodeWeights tracks the weight each node was added with, so Remove
+	// can regenerate and drop exactly the replicas Add created for it.
+	nodeWeights map[string]int
+}
+
+// Option configures a Map constructed with New.
+type Option func(*Map)
+
+// WithHash sets the hashing algorithm. Defaults to crc32.ChecksumIEEE.
+func WithHash(fn Hash) Option {
+	return func(m *Map) {
+		m.hash = fn
+	}
 }
 
-// TODO: add functional opts
+// WithReplicas sets the number of virtual replicas a node of weight 1
+// gets on the ring.
+func WithReplicas(replicas int) Option {
+	return func(m *Map) {
+		m.replicas = replicas
+	}
+}
+
+// WithWeightScaler sets the function used to turn a node's weight into
+// a replica multiplier. Defaults to the identity function, so a node of
+// weight w gets replicas*w virtual replicas.
+func WithWeightScaler(fn func(weight int) int) Option {
+	return func(m *Map) {
+		m.weightScaler = fn
+	}
+}
 
-func New(replicas int, hashFn Hash) *Map {
+func New(opts ...Option) *Map {
 	mp := &Map{
-		hash:     hashFn,
-		replicas: replicas,
-		hashMap:  make(map[int]string),
+		hashMap:     make(map[int]string),
+		nodeWeights: make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(mp)
+	}
+	if mp.replicas == 0 {
+		mp.replicas = defaultReplicas
 	}
 	if mp.hash == nil {
 		mp.hash = crc32.ChecksumIEEE
 	}
+	if mp.weightScaler == nil {
+		mp.weightScaler = func(weight int) int { return weight }
+	}
 	return mp
 }
 
-// Add adds provided keys to the hash.
+// Add adds the provided keys to the hash, each with a weight of 1.
 func (m *Map) Add(keys ...string) {
 	for _, key := range keys {
-		for i := 0; i < m.replicas; i++ {
-			hash := int(m.hash([]byte(strconv.Itoa(i) + key)))
-			m.keys = append(m.keys, hash)
-			m.hashMap[hash] = key
+		m.addNode(key, 1)
+	}
+}
+
+// AddWeighted adds the provided keys to the hash, each getting
+// replicas*weightScaler(weight) virtual replicas instead of the plain
+// replica count, so heavier peers are picked proportionally more often.
+func (m *Map) AddWeighted(weight int, keys ...string) {
+	for _, key := range keys {
+		m.addNode(key, weight)
+	}
+}
+
+func (m *Map) addNode(key string, weight int) {
+	replicas := m.replicas * m.weightScaler(weight)
+	for i := 0; i < replicas; i++ {
+		hash := int(m.hash([]byte(strconv.Itoa(i) + key)))
+		m.keys = append(m.keys, hash)
+		m.hashMap[hash] = key
+	}
+	m.nodeWeights[key] = weight
+	sort.Ints(m.keys)
+}
+
+// Remove drops a node's replicas from the ring, so it no longer owns
+// any keys. It's a no-op if the node was never added.
+func (m *Map) Remove(key string) {
+	weight, ok := m.nodeWeights[key]
+	if !ok {
+		return
+	}
+	replicas := m.replicas * m.weightScaler(weight)
+	for i := 0; i < replicas; i++ {
+		hash := int(m.hash([]byte(strconv.Itoa(i) + key)))
+		delete(m.hashMap, hash)
+	}
+	delete(m.nodeWeights, key)
+
+	kept := m.keys[:0]
+	for _, hash := range m.keys {
+		if _, ok := m.hashMap[hash]; ok {
+			kept = append(kept, hash)
 		}
 	}
+	m.keys = kept
 	sort.Ints(m.keys)
 }
 
@@ -60,6 +140,56 @@ func (m *Map) Get(key string) string {
 	return m.hashMap[m.keys[idx]]
 }
 
+// GetLeast is a bounded-load variant of Get. It walks the ring starting
+// at key's primary owner and returns the first node whose load does not
+// exceed avgFactor times the mean load across all nodes, falling back
+// to the primary owner if every node on the ring is over that bound.
+func (m *Map) GetLeast(key string, load func(node string) float64, avgFactor float64) string {
+	if m.IsEmpty() {
+		return ""
+	}
+	nodes := m.distinctNodes()
+	if len(nodes) == 0 {
+		return ""
+	}
+	var total float64
+	for _, node := range nodes {
+		total += load(node)
+	}
+	threshold := avgFactor * (total / float64(len(nodes)))
+
+	hash := int(m.hash([]byte(key)))
+	start := sort.Search(len(m.keys), func(i int) bool {
+		return m.keys[i] >= hash
+	})
+
+	var primary string
+	seen := make(map[string]bool, len(nodes))
+	for i := 0; i < len(m.keys); i++ {
+		node := m.hashMap[m.keys[(start+i)%len(m.keys)]]
+		if primary == "" {
+			primary = node
+		}
+		if seen[node] {
+			continue
+		}
+		seen[node] = true
+		if load(node) <= threshold {
+			return node
+		}
+	}
+	// every node is over the bound: fall back to the primary owner.
+	return primary
+}
+
+func (m *Map) distinctNodes() []string {
+	nodes := make([]string, 0, len(m.nodeWeights))
+	for node := range m.nodeWeights {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
 // IsEmpty returns true if there are no items available.
 func (m *Map) IsEmpty() bool {
 	return len(m.keys) == 0
@@ -0,0 +1,71 @@
+package consistenthash
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetConsistency(t *testing.T) {
+	m := New(WithReplicas(3), WithHash(func(key []byte) uint32 {
+		i, _ := strconv.Atoi(string(key))
+		return uint32(i)
+	}))
+
+	m.Add("6", "4", "2")
+
+	testCases := map[string]string{
+		"2":  "2",
+		"11": "2",
+		"23": "4",
+		"27": "2",
+	}
+	for k, want := range testCases {
+		require.Equalf(t, want, m.Get(k), "TestGetConsistency: Get(%s): got=%s, want=%s", k, m.Get(k), want)
+	}
+
+	m.Add("8", "18")
+
+	testCases["27"] = "8"
+	testCases["7"] = "8"
+	for k, want := range testCases {
+		require.Equalf(t, want, m.Get(k), "TestGetConsistency: Get(%s) after Add: got=%s, want=%s", k, m.Get(k), want)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	m := New(WithReplicas(3), WithHash(func(key []byte) uint32 {
+		i, _ := strconv.Atoi(string(key))
+		return uint32(i)
+	}))
+
+	m.Add("6", "4", "2")
+	require.Equalf(t, "2", m.Get("2"), "TestRemove: got=%s, want=%s", m.Get("2"), "2")
+
+	m.Remove("2")
+	require.Equalf(t, "4", m.Get("2"), "TestRemove: key should now land on the next node: got=%s, want=%s", m.Get("2"), "4")
+}
+
+func TestAddWeighted(t *testing.T) {
+	m := New(WithReplicas(10))
+	m.Add("light")
+	m.AddWeighted(3, "heavy")
+
+	counts := map[string]int{}
+	for _, node := range m.hashMap {
+		counts[node]++
+	}
+	require.Equalf(t, 10, counts["light"], "TestAddWeighted: light replicas: got=%d, want=%d", counts["light"], 10)
+	require.Equalf(t, 30, counts["heavy"], "TestAddWeighted: heavy replicas: got=%d, want=%d", counts["heavy"], 30)
+}
+
+func TestGetLeastFallsBackWhenSaturated(t *testing.T) {
+	m := New(WithReplicas(3))
+	m.Add("a", "b")
+
+	saturated := func(node string) float64 { return 100 }
+	primary := m.Get("some-key")
+	require.Equalf(t, primary, m.GetLeast("some-key", saturated, 0.5),
+		"TestGetLeastFallsBackWhenSaturated: expected fallback to primary owner %s", primary)
+}
@@ -0,0 +1,351 @@
+package p2pcache
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"peer2peer-cache/lru"
+	pb "peer2peer-cache/p2pcachepb/v1"
+	"peer2peer-cache/singleflight"
+)
+
+// hotCacheShare is the fraction of cacheBytes given to the hotCache,
+// relative to the mainCache. A hotCache of 1/8th the size of mainCache
+// is enough to shortcut most repeated network hops for hot keys without
+// meaningfully shrinking the budget available to keys this peer owns.
+const hotCacheShare = 8
+
+// hotCacheSampleRate controls how often a remote hit is copied into the
+// hotCache: roughly 1 in hotCacheSampleRate requests.
+const hotCacheSampleRate = 10
+
+// Getter loads data for a key that is not already present in the cache.
+type Getter interface {
+	// Get fills dest with the value for key. Implementations must call
+	// exactly one of dest's Set methods.
+	Get(ctx context.Context, key string, dest Sink) error
+}
+
+// GetterFunc allows a plain function to act as a Getter.
+type GetterFunc func(ctx context.Context, key string, dest Sink) error
+
+func (f GetterFunc) Get(ctx context.Context, key string, dest Sink) error {
+	return f(ctx, key, dest)
+}
+
+// Group is a cache namespace and associated data loaded spread over
+// a group of peers.
+type Group struct {
+	name   string
+	getter Getter
+
+	peersOnce sync.Once
+	peers     PeerPicker
+
+	// cacheBytes is the total byte budget split between mainCache and
+	// hotCache.
+	cacheBytes int64
+
+	// mainCache holds the keys this peer owns, per the consistent hash.
+	mainCache cache
+
+	// hotCache holds a small fraction of keys owned by other peers but
+	// recently requested locally, to shortcut network hops for hot keys.
+	hotCache cache
+
+	// loadGroup deduplicates concurrent loads for the same key, so
+	// that only one fetch from the Getter or a peer is in flight at
+	// a time regardless of how many callers ask for it.
+	loadGroup singleflight.Group
+
+	// removeGroup deduplicates concurrent Remove calls for the same
+	// key, the same way loadGroup deduplicates loads.
+	removeGroup singleflight.Group
+}
+
+var (
+	mu     sync.RWMutex
+	groups = make(map[string]*Group)
+)
+
+// NewGroup creates a new Group of the given name and registers it, so
+// it can later be looked up with GetGroup. cacheBytes is the total byte
+// budget for the group, split between its mainCache and hotCache.
+func NewGroup(name string, cacheBytes int64, getter Getter) *Group {
+	if getter == nil {
+		panic("p2pcache: nil Getter")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+
+	g := &Group{
+		name:       name,
+		getter:     getter,
+		cacheBytes: cacheBytes,
+	}
+	g.hotCache.maxBytes = cacheBytes / (hotCacheShare + 1)
+	g.mainCache.maxBytes = cacheBytes - g.hotCache.maxBytes
+	groups[name] = g
+	return g
+}
+
+// GetGroup returns the named group previously created with NewGroup, or
+// nil if there's no such group.
+func GetGroup(name string) *Group {
+	mu.RLock()
+	defer mu.RUnlock()
+	return groups[name]
+}
+
+// Name returns the name of the group.
+func (g *Group) Name() string {
+	return g.name
+}
+
+func (g *Group) initPeers() {
+	if portPicker != nil {
+		g.peers = portPicker(g.name)
+	}
+}
+
+// Get fills dest with the value for key, fetching it from the local
+// cache, a peer, or the Getter, in that order.
+func (g *Group) Get(ctx context.Context, key string, dest Sink) error {
+	g.peersOnce.Do(g.initPeers)
+	if dest == nil {
+		return errors.New("p2pcache: nil dest Sink")
+	}
+	if key == "" {
+		return errors.New("p2pcache: empty key")
+	}
+	if value, expireAt, ok := g.lookupCache(key); ok {
+		return setSinkViewWithExpire(dest, value, expireAt)
+	}
+	value, err := g.load(ctx, key, dest)
+	if err != nil {
+		return err
+	}
+	return setSinkView(dest, value)
+}
+
+// load loads key either by invoking the getter locally or by sending it
+// to a peer, deduplicating concurrent requests for the same key. It
+// honors ctx's deadline: if ctx is done before the in-flight call
+// completes, load returns ctx.Err() without waiting for or affecting
+// the call, which keeps running for any other caller sharing it.
+func (g *Group) load(ctx context.Context, key string, dest Sink) (ByteView, error) {
+	ch := g.loadGroup.DoChan(key, func() (any, error) {
+		if g.peers != nil {
+			if peer, ok := g.peers.PeerPicker(key); ok {
+				if value, err := g.getFromPeer(ctx, peer, key); err == nil {
+					return value, nil
+				}
+			}
+		}
+		return g.getLocally(ctx, key, dest)
+	})
+
+	select {
+	case <-ctx.Done():
+		return ByteView{}, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return ByteView{}, res.Err
+		}
+		return res.Val.(ByteView), nil
+	}
+}
+
+func (g *Group) getLocally(ctx context.Context, key string, dest Sink) (ByteView, error) {
+	if err := g.getter.Get(ctx, key, dest); err != nil {
+		return ByteView{}, err
+	}
+	value, err := dest.view()
+	if err != nil {
+		return ByteView{}, err
+	}
+	var expireAt time.Time
+	if eg, ok := dest.(expireGetter); ok {
+		expireAt = eg.expireAt()
+	}
+	g.mainCache.addWithExpire(key, value, expireAt)
+	return value, nil
+}
+
+func (g *Group) getFromPeer(ctx context.Context, peer ProtoGetter, key string) (ByteView, error) {
+	in := &pb.GetRequest{Group: &g.name, Key: &key}
+	out := new(pb.GetResponse)
+	if err := peer.Get(ctx, in, out); err != nil {
+		return ByteView{}, err
+	}
+	value := ByteView{b: out.GetValue()}
+	if rand.Intn(hotCacheSampleRate) == 0 {
+		var expireAt time.Time
+		if out.ExpireAtUnixNano != nil {
+			expireAt = time.Unix(0, out.GetExpireAtUnixNano())
+		}
+		g.hotCache.addWithExpire(key, value, expireAt)
+	}
+	return value, nil
+}
+
+// lookupCache checks the mainCache, then the hotCache, also returning
+// the expireAt recorded alongside the hit, if any.
+func (g *Group) lookupCache(key string) (ByteView, time.Time, bool) {
+	if value, expireAt, ok := g.mainCache.getWithExpire(key); ok {
+		return value, expireAt, true
+	}
+	return g.hotCache.getWithExpire(key)
+}
+
+// Remove evicts key from this peer's caches and fans the eviction out to
+// every other registered peer, so a key can be invalidated across the
+// cluster without restarting any node. Concurrent Remove calls for the
+// same key are deduplicated. The first peer error encountered is
+// returned, but every peer is still attempted.
+func (g *Group) Remove(ctx context.Context, key string) error {
+	g.peersOnce.Do(g.initPeers)
+	_, err := g.removeGroup.Do(key, func() (any, error) {
+		return nil, g.removeFromAll(ctx, key)
+	})
+	return err
+}
+
+func (g *Group) removeFromAll(ctx context.Context, key string) error {
+	g.mainCache.remove(key)
+	g.hotCache.remove(key)
+
+	if g.peers == nil {
+		return nil
+	}
+	var firstErr error
+	for _, peer := range g.peers.AllPeers() {
+		in := &pb.RemoveRequest{Group: &g.name, Key: &key}
+		if err := peer.Remove(ctx, in); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// CacheType identifies one of a Group's two cache tiers.
+type CacheType uint8
+
+const (
+	// MainCache holds keys this peer owns per the consistent hash.
+	MainCache CacheType = iota + 1
+
+	// HotCache holds keys owned by other peers but recently requested
+	// locally.
+	HotCache
+)
+
+// CacheStats reports usage counters for one of a Group's cache tiers.
+type CacheStats struct {
+	Bytes     int64
+	Items     int64
+	Gets      int64
+	Hits      int64
+	Evictions int64
+}
+
+// CacheStats returns usage counters for the requested cache tier.
+func (g *Group) CacheStats(which CacheType) CacheStats {
+	switch which {
+	case MainCache:
+		return g.mainCache.stats()
+	case HotCache:
+		return g.hotCache.stats()
+	default:
+		return CacheStats{}
+	}
+}
+
+// cache wraps an lru.LruCache with a mutex and usage counters, since
+// LruCache is not safe for concurrent use on its own and is budgeted by
+// bytes rather than entry count.
+type cache struct {
+	mu       sync.RWMutex
+	lru      *lru.LruCache
+	maxBytes int64
+
+	nget, nhit, nevict int64
+}
+
+func (c *cache) lazyInit() {
+	if c.lru != nil {
+		return
+	}
+	c.lru = lru.NewLru(0)
+	c.lru.MaxBytes = c.maxBytes
+	c.lru.SizeOf = cacheEntrySize
+	c.lru.OnEvicted = func(_ lru.Key, _ any) {
+		c.nevict++
+	}
+}
+
+func (c *cache) add(key string, value ByteView) {
+	c.addWithExpire(key, value, time.Time{})
+}
+
+func (c *cache) addWithExpire(key string, value ByteView, expireAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lazyInit()
+	c.lru.AddWithExpire(key, value, expireAt)
+}
+
+func (c *cache) get(key string) (value ByteView, ok bool) {
+	value, _, ok = c.getWithExpire(key)
+	return value, ok
+}
+
+func (c *cache) getWithExpire(key string) (value ByteView, expireAt time.Time, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lru == nil {
+		return
+	}
+	c.nget++
+	v, expireAt, ok := c.lru.GetWithExpire(key)
+	if !ok {
+		return ByteView{}, time.Time{}, false
+	}
+	c.nhit++
+	return v.(ByteView), expireAt, true
+}
+
+func (c *cache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lru == nil {
+		return
+	}
+	c.lru.Remove(key)
+}
+
+func (c *cache) stats() CacheStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	stats := CacheStats{
+		Gets:      c.nget,
+		Hits:      c.nhit,
+		Evictions: c.nevict,
+	}
+	if c.lru != nil {
+		stats.Bytes = c.lru.Bytes()
+		stats.Items = int64(c.lru.Len())
+	}
+	return stats
+}
+
+// cacheEntrySize estimates the memory footprint of a cache entry, used
+// to budget the cache by bytes rather than entry count.
+func cacheEntrySize(key lru.Key, value any) int64 {
+	k, _ := key.(string)
+	v := value.(ByteView)
+	return int64(len(k)) + int64(v.Len()) + 8
+}
@@ -0,0 +1,167 @@
+package p2pcache
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "peer2peer-cache/p2pcachepb/v1"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupGetCachesAfterFirstLoad(t *testing.T) {
+	var calls int32
+	getter := GetterFunc(func(_ context.Context, key string, dest Sink) error {
+		atomic.AddInt32(&calls, 1)
+		return dest.SetString("value-of-" + key)
+	})
+	g := NewGroup(fmt.Sprintf("TestGroupGetCachesAfterFirstLoad-%p", &calls), 1<<20, getter)
+
+	var got string
+	require.NoError(t, g.Get(context.Background(), "foo", StringSink(&got)),
+		"TestGroupGetCachesAfterFirstLoad: Get returned unexpected error")
+	require.Equal(t, "value-of-foo", got)
+
+	got = ""
+	require.NoError(t, g.Get(context.Background(), "foo", StringSink(&got)),
+		"TestGroupGetCachesAfterFirstLoad: second Get returned unexpected error")
+	require.Equal(t, "value-of-foo", got)
+
+	require.Equalf(t, int32(1), atomic.LoadInt32(&calls),
+		"TestGroupGetCachesAfterFirstLoad: getter called %d times, want=1", calls)
+
+	stats := g.CacheStats(MainCache)
+	require.Equalf(t, int64(1), stats.Hits, "TestGroupGetCachesAfterFirstLoad: mainCache hits: got=%d, want=1", stats.Hits)
+}
+
+func TestGroupGetRejectsEmptyKeyAndNilDest(t *testing.T) {
+	getter := GetterFunc(func(_ context.Context, _ string, _ Sink) error {
+		return nil
+	})
+	g := NewGroup("TestGroupGetRejectsEmptyKeyAndNilDest", 1<<20, getter)
+
+	var got string
+	require.Error(t, g.Get(context.Background(), "", StringSink(&got)),
+		"TestGroupGetRejectsEmptyKeyAndNilDest: expected error for empty key")
+	require.Error(t, g.Get(context.Background(), "foo", nil),
+		"TestGroupGetRejectsEmptyKeyAndNilDest: expected error for nil dest")
+}
+
+// fakePeer implements ProtoGetter, recording every Get/Remove it receives.
+type fakePeer struct {
+	getCalls    int32
+	removeCalls int32
+
+	// expireIn, if non-zero, is reported to the caller as the origin TTL
+	// on every Get response, relative to when the Get is served.
+	expireIn time.Duration
+}
+
+func (p *fakePeer) Get(_ context.Context, in *pb.GetRequest, out *pb.GetResponse) error {
+	atomic.AddInt32(&p.getCalls, 1)
+	out.Value = []byte("from-peer-" + in.GetKey())
+	if p.expireIn != 0 {
+		nano := time.Now().Add(p.expireIn).UnixNano()
+		out.ExpireAtUnixNano = &nano
+	}
+	return nil
+}
+
+func (p *fakePeer) Remove(_ context.Context, _ *pb.RemoveRequest) error {
+	atomic.AddInt32(&p.removeCalls, 1)
+	return nil
+}
+
+// fakePeerPicker always routes to the same peer, for every key.
+type fakePeerPicker struct {
+	peer *fakePeer
+}
+
+func (pp fakePeerPicker) PeerPicker(_ string) (ProtoGetter, bool) {
+	return pp.peer, true
+}
+
+func (pp fakePeerPicker) AllPeers() []ProtoGetter {
+	return []ProtoGetter{pp.peer}
+}
+
+func TestGroupGetFromPeer(t *testing.T) {
+	getter := GetterFunc(func(_ context.Context, _ string, _ Sink) error {
+		t.Fatal("TestGroupGetFromPeer: local getter should not be called when a peer owns the key")
+		return nil
+	})
+	g := NewGroup("TestGroupGetFromPeer", 1<<20, getter)
+
+	peer := &fakePeer{}
+	g.peersOnce.Do(func() {}) // pretend peer init already ran
+	g.peers = fakePeerPicker{peer: peer}
+
+	var got string
+	require.NoError(t, g.Get(context.Background(), "foo", StringSink(&got)),
+		"TestGroupGetFromPeer: Get returned unexpected error")
+	require.Equal(t, "from-peer-foo", got)
+	require.Equalf(t, int32(1), atomic.LoadInt32(&peer.getCalls),
+		"TestGroupGetFromPeer: peer Get calls: got=%d, want=1", peer.getCalls)
+}
+
+func TestGroupGetFromPeerForwardsOriginTTLToHotCache(t *testing.T) {
+	getter := GetterFunc(func(_ context.Context, _ string, _ Sink) error {
+		t.Fatal("TestGroupGetFromPeerForwardsOriginTTLToHotCache: local getter should not be called when a peer owns the key")
+		return nil
+	})
+	g := NewGroup(fmt.Sprintf("TestGroupGetFromPeerForwardsOriginTTLToHotCache-%p", &getter), 1<<20, getter)
+
+	peer := &fakePeer{expireIn: 50 * time.Millisecond}
+
+	// getFromPeer only samples into hotCache ~1 in hotCacheSampleRate calls,
+	// so keep calling it directly until the sample lands.
+	var cached bool
+	for i := 0; i < 500 && !cached; i++ {
+		_, err := g.getFromPeer(context.Background(), peer, "foo")
+		require.NoError(t, err, "TestGroupGetFromPeerForwardsOriginTTLToHotCache: getFromPeer returned unexpected error")
+		_, cached = g.hotCache.get("foo")
+	}
+	require.Truef(t, cached,
+		"TestGroupGetFromPeerForwardsOriginTTLToHotCache: hotCache never sampled the peer value in %d attempts", 500)
+
+	time.Sleep(100 * time.Millisecond)
+	_, ok := g.hotCache.get("foo")
+	require.False(t, ok,
+		"TestGroupGetFromPeerForwardsOriginTTLToHotCache: hotCache entry should have expired per the origin's TTL")
+}
+
+func TestGroupRemoveEvictsLocallyAndFansOutToPeers(t *testing.T) {
+	getter := GetterFunc(func(_ context.Context, key string, dest Sink) error {
+		return dest.SetString("value-of-" + key)
+	})
+	g := NewGroup("TestGroupRemoveEvictsLocallyAndFansOutToPeers", 1<<20, getter)
+
+	var got string
+	require.NoError(t, g.Get(context.Background(), "foo", StringSink(&got)),
+		"TestGroupRemoveEvictsLocallyAndFansOutToPeers: Get returned unexpected error")
+
+	peer := &fakePeer{}
+	g.peersOnce.Do(func() {})
+	g.peers = fakePeerPicker{peer: peer}
+
+	require.NoError(t, g.Remove(context.Background(), "foo"),
+		"TestGroupRemoveEvictsLocallyAndFansOutToPeers: Remove returned unexpected error")
+
+	_, ok := g.mainCache.get("foo")
+	require.False(t, ok, "TestGroupRemoveEvictsLocallyAndFansOutToPeers: key should be evicted from mainCache")
+	require.Equalf(t, int32(1), atomic.LoadInt32(&peer.removeCalls),
+		"TestGroupRemoveEvictsLocallyAndFansOutToPeers: peer Remove calls: got=%d, want=1", peer.removeCalls)
+}
+
+func TestNewGroupSplitsCacheBytes(t *testing.T) {
+	g := NewGroup("TestNewGroupSplitsCacheBytes", 900, GetterFunc(func(_ context.Context, _ string, _ Sink) error {
+		return nil
+	}))
+
+	require.Equalf(t, g.cacheBytes, g.mainCache.maxBytes+g.hotCache.maxBytes,
+		"TestNewGroupSplitsCacheBytes: mainCache.maxBytes+hotCache.maxBytes: got=%d, want=%d",
+		g.mainCache.maxBytes+g.hotCache.maxBytes, g.cacheBytes)
+}
@@ -0,0 +1,240 @@
+// Package httppool implements p2pcache.PeerPicker and p2pcache.ProtoGetter
+// over plain HTTP, so a Group can fan requests out to its peers without
+// pulling in a full RPC framework.
+package httppool
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	p2pcache "peer2peer-cache"
+	"peer2peer-cache/consistenthash"
+	pb "peer2peer-cache/p2pcachepb/v1"
+)
+
+const defaultBasePath = "/_p2pcache/"
+
+const defaultReplicas = 50
+
+// HTTPPoolOptions configures a HTTPPool. All fields are optional and
+// fall back to sensible defaults when the zero value is used.
+type HTTPPoolOptions struct {
+	// BasePath specifies the HTTP path under which requests are served.
+	// Defaults to "/_p2pcache/".
+	BasePath string
+
+	// Replicas specifies the number of virtual replicas the consistent
+	// hash ring keeps per peer. Defaults to 50.
+	Replicas int
+
+	// HashFn specifies the hash function for the consistent hash ring.
+	// Defaults to crc32.ChecksumIEEE.
+	HashFn consistenthash.Hash
+}
+
+// HTTPPool implements p2pcache.PeerPicker for a pool of HTTP peers, and
+// itself serves p2pcache.ProtoGetter requests arriving over HTTP.
+type HTTPPool struct {
+	// self is this peer's base URL, e.g. "https://10.0.0.1:8000".
+	self string
+
+	basePath string
+	replicas int
+	hashFn   consistenthash.Hash
+
+	// Client is used to make peer requests. If nil, http.DefaultClient
+	// is used.
+	Client *http.Client
+
+	mu          sync.Mutex // protects peers and httpGetters
+	peers       *consistenthash.Map
+	httpGetters map[string]*httpGetter // keyed by peer base URL
+}
+
+// NewHTTPPool initializes an HTTPPool with default options, registers it
+// as the PeerPicker for this process, and returns it. It must be called
+// only once.
+func NewHTTPPool(self string) *HTTPPool {
+	return NewHTTPPoolOpts(self, nil)
+}
+
+// NewHTTPPoolOpts is like NewHTTPPool but accepts HTTPPoolOptions. It
+// must be called only once.
+func NewHTTPPoolOpts(self string, o *HTTPPoolOptions) *HTTPPool {
+	p := &HTTPPool{
+		self:     self,
+		basePath: defaultBasePath,
+		replicas: defaultReplicas,
+	}
+	if o != nil {
+		if o.BasePath != "" {
+			p.basePath = o.BasePath
+		}
+		if o.Replicas != 0 {
+			p.replicas = o.Replicas
+		}
+		p.hashFn = o.HashFn
+	}
+	p.Set(self)
+	p2pcache.RegisterPeerPicker(func() p2pcache.PeerPicker { return p })
+	return p
+}
+
+// Set updates the pool's list of peers, replacing any previously known
+// ones, including self.
+func (p *HTTPPool) Set(peers ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.peers = consistenthash.New(
+		consistenthash.WithReplicas(p.replicas),
+		consistenthash.WithHash(p.hashFn),
+	)
+	p.peers.Add(peers...)
+	p.httpGetters = make(map[string]*httpGetter, len(peers))
+	for _, peer := range peers {
+		p.httpGetters[peer] = &httpGetter{pool: p, baseURL: peer + p.basePath}
+	}
+}
+
+// PeerPicker implements p2pcache.PeerPicker.
+func (p *HTTPPool) PeerPicker(key string) (p2pcache.ProtoGetter, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.peers.IsEmpty() {
+		return nil, false
+	}
+	if peer := p.peers.Get(key); peer != "" && peer != p.self {
+		return p.httpGetters[peer], true
+	}
+	return nil, false
+}
+
+// AllPeers implements p2pcache.PeerPicker, returning every peer other
+// than self.
+func (p *HTTPPool) AllPeers() []p2pcache.ProtoGetter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	getters := make([]p2pcache.ProtoGetter, 0, len(p.httpGetters))
+	for peer, getter := range p.httpGetters {
+		if peer == p.self {
+			continue
+		}
+		getters = append(getters, getter)
+	}
+	return getters
+}
+
+func (p *HTTPPool) httpClient() *http.Client {
+	if p.Client != nil {
+		return p.Client
+	}
+	return http.DefaultClient
+}
+
+// ServeHTTP handles requests of the form "<basePath>/<group>/<key>",
+// looking up the named local Group and either serving a Get or, for
+// DELETE requests, a Remove.
+func (p *HTTPPool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, p.basePath) {
+		http.Error(w, "p2pcache: unexpected path: "+r.URL.Path, http.StatusBadRequest)
+		return
+	}
+	parts := strings.SplitN(r.URL.Path[len(p.basePath):], "/", 2)
+	if len(parts) != 2 {
+		http.Error(w, "p2pcache: bad request", http.StatusBadRequest)
+		return
+	}
+	groupName, err := url.PathUnescape(parts[0])
+	if err != nil {
+		groupName = parts[0]
+	}
+	key, err := url.PathUnescape(parts[1])
+	if err != nil {
+		key = parts[1]
+	}
+
+	group := p2pcache.GetGroup(groupName)
+	if group == nil {
+		http.Error(w, "p2pcache: no such group: "+groupName, http.StatusNotFound)
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		if err := group.Remove(r.Context(), key); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	var value []byte
+	sink := p2pcache.AllocatingByteSliceSink(&value)
+	if err := group.Get(r.Context(), key, sink); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := &pb.GetResponse{Value: value}
+	if expireAt, ok := p2pcache.Expiry(sink); ok {
+		nano := expireAt.UnixNano()
+		resp.ExpireAtUnixNano = &nano
+	}
+	body, err := proto.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = w.Write(body)
+}
+
+// httpGetter is the p2pcache.ProtoGetter implementation used for every
+// remote peer registered with the pool.
+type httpGetter struct {
+	pool    *HTTPPool
+	baseURL string
+}
+
+func (h *httpGetter) Get(ctx context.Context, in *pb.GetRequest, out *pb.GetResponse) error {
+	resBody, err := h.do(ctx, http.MethodGet, in.GetGroup(), in.GetKey())
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(resBody, out)
+}
+
+func (h *httpGetter) Remove(ctx context.Context, in *pb.RemoveRequest) error {
+	_, err := h.do(ctx, http.MethodDelete, in.GetGroup(), in.GetKey())
+	return err
+}
+
+func (h *httpGetter) do(ctx context.Context, method, group, key string) ([]byte, error) {
+	u := fmt.Sprintf("%v%v/%v", h.baseURL, url.PathEscape(group), url.PathEscape(key))
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := h.pool.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("p2pcache: reading response body: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("p2pcache: server returned status %v: %s", res.StatusCode, resBody)
+	}
+	return resBody, nil
+}
@@ -0,0 +1,109 @@
+package httppool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	p2pcache "peer2peer-cache"
+	pb "peer2peer-cache/p2pcachepb/v1"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errBoom = errors.New("httppool: boom")
+
+func newTestPool() *HTTPPool {
+	return &HTTPPool{basePath: defaultBasePath, replicas: defaultReplicas}
+}
+
+func TestServeHTTPRejectsBadPaths(t *testing.T) {
+	p := newTestPool()
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/wrong-prefix/foo/bar", nil))
+	require.Equalf(t, http.StatusBadRequest, rec.Code, "TestServeHTTPRejectsBadPaths: path outside basePath: got=%d, want=%d", rec.Code, http.StatusBadRequest)
+
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, defaultBasePath+"onlygroup", nil))
+	require.Equalf(t, http.StatusBadRequest, rec.Code, "TestServeHTTPRejectsBadPaths: missing key segment: got=%d, want=%d", rec.Code, http.StatusBadRequest)
+}
+
+func TestServeHTTPUnknownGroup(t *testing.T) {
+	p := newTestPool()
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, defaultBasePath+"no-such-group/key", nil))
+	require.Equalf(t, http.StatusNotFound, rec.Code, "TestServeHTTPUnknownGroup: got=%d, want=%d", rec.Code, http.StatusNotFound)
+}
+
+func TestServeHTTPGetAndRemove(t *testing.T) {
+	p := newTestPool()
+	p2pcache.NewGroup("TestServeHTTPGetAndRemove", 1<<20, p2pcache.GetterFunc(
+		func(_ context.Context, key string, dest p2pcache.Sink) error {
+			return dest.SetString("value-of-" + key)
+		}))
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, defaultBasePath+"TestServeHTTPGetAndRemove/foo", nil))
+	require.Equalf(t, http.StatusOK, rec.Code, "TestServeHTTPGetAndRemove: Get: got=%d, want=%d", rec.Code, http.StatusOK)
+
+	var out pb.GetResponse
+	require.NoError(t, proto.Unmarshal(rec.Body.Bytes(), &out), "TestServeHTTPGetAndRemove: decoding GetResponse")
+	require.Equal(t, "value-of-foo", string(out.GetValue()))
+
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, defaultBasePath+"TestServeHTTPGetAndRemove/foo", nil))
+	require.Equalf(t, http.StatusOK, rec.Code, "TestServeHTTPGetAndRemove: Remove: got=%d, want=%d", rec.Code, http.StatusOK)
+}
+
+func TestServeHTTPGetterErrorReturnsServerError(t *testing.T) {
+	p := newTestPool()
+	p2pcache.NewGroup("TestServeHTTPGetterErrorReturnsServerError", 1<<20, p2pcache.GetterFunc(
+		func(_ context.Context, _ string, _ p2pcache.Sink) error {
+			return errBoom
+		}))
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, defaultBasePath+"TestServeHTTPGetterErrorReturnsServerError/foo", nil))
+	require.Equalf(t, http.StatusInternalServerError, rec.Code, "TestServeHTTPGetterErrorReturnsServerError: got=%d, want=%d", rec.Code, http.StatusInternalServerError)
+}
+
+func TestHTTPGetterGetAndRemove(t *testing.T) {
+	p := newTestPool()
+	p2pcache.NewGroup("TestHTTPGetterGetAndRemove", 1<<20, p2pcache.GetterFunc(
+		func(_ context.Context, key string, dest p2pcache.Sink) error {
+			return dest.SetString("value-of-" + key)
+		}))
+
+	srv := httptest.NewServer(p)
+	defer srv.Close()
+
+	getter := &httpGetter{pool: p, baseURL: srv.URL + p.basePath}
+
+	out := new(pb.GetResponse)
+	in := &pb.GetRequest{Group: strp("TestHTTPGetterGetAndRemove"), Key: strp("foo")}
+	require.NoError(t, getter.Get(context.Background(), in, out), "TestHTTPGetterGetAndRemove: Get returned unexpected error")
+	require.Equal(t, "value-of-foo", string(out.GetValue()))
+
+	rin := &pb.RemoveRequest{Group: strp("TestHTTPGetterGetAndRemove"), Key: strp("foo")}
+	require.NoError(t, getter.Remove(context.Background(), rin), "TestHTTPGetterGetAndRemove: Remove returned unexpected error")
+}
+
+func TestHTTPGetterSurfacesServerError(t *testing.T) {
+	p := newTestPool()
+
+	srv := httptest.NewServer(p)
+	defer srv.Close()
+
+	getter := &httpGetter{pool: p, baseURL: srv.URL + p.basePath}
+	in := &pb.GetRequest{Group: strp("no-such-group"), Key: strp("foo")}
+	err := getter.Get(context.Background(), in, new(pb.GetResponse))
+	require.Error(t, err, "TestHTTPGetterSurfacesServerError: expected error for unknown group")
+}
+
+func strp(s string) *string { return &s }
@@ -1,6 +1,9 @@
 package lru
 
-import "container/list"
+import (
+	"container/list"
+	"time"
+)
 
 // LruCache is not safe for concurrent access.
 type LruCache struct {
@@ -8,6 +11,14 @@ type LruCache struct {
 	// Zero means no limit.
 	MaxEntries int
 
+	// MaxBytes is the total size in bytes, as reported by SizeOf, before
+	// the oldest items are evicted. Zero means no byte-based limit.
+	MaxBytes int64
+
+	// SizeOf reports the size in bytes of a key-value pair, and is
+	// required for MaxBytes to have any effect.
+	SizeOf func(key Key, value any) int64
+
 	// OnEvicted specifies a callback function to be executed when an entry
 	// is removed from cache.
 	OnEvicted func(key Key, value any)
@@ -17,6 +28,9 @@ type LruCache struct {
 
 	// cache holds key-value pairs of list elements in memory until evicted.
 	cache map[any]*list.Element
+
+	// nbytes is the sum of SizeOf over every entry currently cached.
+	nbytes int64
 }
 
 // Key may be a value that is comparable.
@@ -25,6 +39,19 @@ type Key any
 type entry struct {
 	key   Key
 	value any
+
+	// expireAt is the absolute time at which this entry becomes stale.
+	// The zero Time means the entry never expires.
+	expireAt time.Time
+
+	// size is this entry's cached SizeOf result, so it can be removed
+	// from nbytes on eviction without calling SizeOf again.
+	size int64
+}
+
+// expired reports whether the entry is past its expireAt, if any.
+func (e *entry) expired() bool {
+	return !e.expireAt.IsZero() && time.Now().After(e.expireAt)
 }
 
 // NewLru creates a new LruCache. If maxEntries is zero then cache has no
@@ -39,32 +66,90 @@ func NewLru(maxEntries int) *LruCache {
 
 // Add adds a value to the cache.
 func (lru *LruCache) Add(key Key, value any) {
+	lru.addWithExpire(key, value, time.Time{})
+}
+
+// AddWithExpire adds a value to the cache that's treated as a miss, and
+// lazily evicted, once expireAt has passed.
+func (lru *LruCache) AddWithExpire(key Key, value any, expireAt time.Time) {
+	lru.addWithExpire(key, value, expireAt)
+}
+
+func (lru *LruCache) addWithExpire(key Key, value any, expireAt time.Time) {
 	if lru.cache == nil {
 		lru.cache = make(map[any]*list.Element)
 		lru.dll = list.New()
 	}
+	size := lru.sizeOf(key, value)
 	if elem, ok := lru.cache[key]; ok {
 		lru.dll.MoveToFront(elem)
-		elem.Value.(*entry).value = value
-		return
+		ent := elem.Value.(*entry)
+		lru.nbytes += size - ent.size
+		ent.value = value
+		ent.expireAt = expireAt
+		ent.size = size
+	} else {
+		elem := lru.dll.PushFront(&entry{key: key, value: value, expireAt: expireAt, size: size})
+		lru.cache[key] = elem
+		lru.nbytes += size
 	}
-	elem := lru.dll.PushFront(&entry{key, value})
-	lru.cache[key] = elem
-	if lru.MaxEntries != 0 && lru.dll.Len() > lru.MaxEntries {
+	for lru.overCapacity() {
 		lru.RemoveOldest()
 	}
 }
 
-// Get returns a key's value if exists.
+func (lru *LruCache) sizeOf(key Key, value any) int64 {
+	if lru.SizeOf == nil {
+		return 0
+	}
+	return lru.SizeOf(key, value)
+}
+
+func (lru *LruCache) overCapacity() bool {
+	if lru.MaxEntries != 0 && lru.dll.Len() > lru.MaxEntries {
+		return true
+	}
+	return lru.MaxBytes != 0 && lru.nbytes > lru.MaxBytes
+}
+
+// Get returns a key's value if exists. An entry past its expireAt is
+// treated as a miss and removed from the cache.
 func (lru *LruCache) Get(key Key) (val any, ok bool) {
+	val, _, ok = lru.GetWithExpire(key)
+	return val, ok
+}
+
+// GetWithExpire is like Get but also returns the entry's expireAt, the
+// zero Time if it never expires.
+func (lru *LruCache) GetWithExpire(key Key) (val any, expireAt time.Time, ok bool) {
 	if lru.cache == nil {
 		return
 	}
-	if elem, hit := lru.cache[key]; hit {
-		lru.dll.MoveToFront(elem)
-		val, ok = elem.Value.(*entry).value, true
+	elem, hit := lru.cache[key]
+	if !hit {
+		return
+	}
+	ent := elem.Value.(*entry)
+	if ent.expired() {
+		lru.removeElement(elem)
+		return
+	}
+	lru.dll.MoveToFront(elem)
+	return ent.value, ent.expireAt, true
+}
+
+// RemoveExpired sweeps the cache and evicts every entry whose expireAt
+// has already passed, for callers that want proactive cleanup instead
+// of waiting for a lazy Get to notice.
+func (lru *LruCache) RemoveExpired() {
+	if lru.cache == nil {
+		return
+	}
+	for _, elem := range lru.cache {
+		if elem.Value.(*entry).expired() {
+			lru.removeElement(elem)
+		}
 	}
-	return val, ok
 }
 
 // Remove removes the provided key from the cache.
@@ -95,6 +180,7 @@ func (lru *LruCache) removeElement(elem *list.Element) {
 	ent := elem.Value.(*entry)
 
 	delete(lru.cache, ent.key)
+	lru.nbytes -= ent.size
 	if lru.OnEvicted != nil {
 		lru.OnEvicted(ent.key, ent.value)
 	}
@@ -108,6 +194,11 @@ func (lru *LruCache) Len() int {
 	return lru.dll.Len()
 }
 
+// Bytes returns the sum of SizeOf over every entry currently cached.
+func (lru *LruCache) Bytes() int64 {
+	return lru.nbytes
+}
+
 // Clear purges the cache and calls OnEvicted on every cache entry.
 func (lru *LruCache) Clear() {
 	if lru.OnEvicted != nil {
@@ -118,4 +209,5 @@ func (lru *LruCache) Clear() {
 	}
 	lru.dll = nil
 	lru.cache = nil
+	lru.nbytes = 0
 }
@@ -3,6 +3,7 @@ package lru
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -68,6 +69,52 @@ func TestRemove(t *testing.T) {
 	require.False(t, ok, "TestRemove: Get returned a removed value")
 }
 
+func TestAddWithExpireLazyEviction(t *testing.T) {
+	evicted := make([]Key, 0)
+	lru := NewLru(0)
+	lru.OnEvicted = func(key Key, _ any) {
+		evicted = append(evicted, key)
+	}
+
+	lru.AddWithExpire("myKey", 1234, time.Now().Add(-time.Minute))
+	_, ok := lru.Get("myKey")
+	require.False(t, ok, "TestAddWithExpireLazyEviction: Get returned an expired value")
+	require.Equalf(t, 1, len(evicted), "got=%d, want=%d", len(evicted), 1)
+}
+
+func TestRemoveExpired(t *testing.T) {
+	lru := NewLru(0)
+	lru.Add("fresh", 1)
+	lru.AddWithExpire("stale", 2, time.Now().Add(-time.Minute))
+
+	lru.RemoveExpired()
+	require.Equalf(t, 1, lru.Len(), "got=%d, want=%d", lru.Len(), 1)
+
+	_, ok := lru.Get("fresh")
+	require.True(t, ok, "TestRemoveExpired: fresh entry was removed")
+}
+
+func TestEvictByBytes(t *testing.T) {
+	lru := NewLru(0)
+	lru.MaxBytes = 10
+	lru.SizeOf = func(key Key, value any) int64 {
+		return int64(len(key.(string))) + int64(len(value.(string)))
+	}
+
+	lru.Add("a", "12345")
+	lru.Add("b", "12345")
+	lru.Add("c", "12345")
+
+	require.Equalf(t, 1, lru.Len(), "got=%d, want=%d", lru.Len(), 1)
+	require.LessOrEqualf(t, lru.Bytes(), int64(10), "bytes %d exceeds MaxBytes %d", lru.Bytes(), 10)
+
+	_, ok := lru.Get("c")
+	require.True(t, ok, "TestEvictByBytes: most recently added entry should survive")
+
+	_, ok = lru.Get("a")
+	require.False(t, ok, "TestEvictByBytes: oldest entry should have been evicted to stay under MaxBytes")
+}
+
 func TestEvict(t *testing.T) {
 	evictedKey := make([]Key, 0)
 	onEvictedFunc := func(key Key, value any) {
@@ -8,6 +8,9 @@ import (
 // ProtoGetter is an interface that must be implemented by a peer.
 type ProtoGetter interface {
 	Get(ctx context.Context, in *pb.GetRequest, out *pb.GetResponse) error
+
+	// Remove asks the peer to evict in.Key from its local caches.
+	Remove(ctx context.Context, in *pb.RemoveRequest) error
 }
 
 // PeerPicker is the interface that must be implemented to locate
@@ -17,6 +20,12 @@ type PeerPicker interface {
 	// true to indicate that a remote peer was nominated.
 	// It returns (nil, false) if the key owner is current peer.
 	PeerPicker(key string) (peer ProtoGetter, ok bool)
+
+	// AllPeers returns every registered peer other than the current
+	// one. It's used for fan-out
+	// operations, such as Remove, that must reach every node
+	// regardless of which one owns the key.
+	AllPeers() []ProtoGetter
 }
 
 // NoPeer is an implementation of PeerPicker that never finds a peer.
@@ -28,6 +37,10 @@ func (NoPeer) PeerPicker(_ string) (peer ProtoGetter, ok bool) {
 	return
 }
 
+func (NoPeer) AllPeers() []ProtoGetter {
+	return nil
+}
+
 var (
 	portPicker func(groupName string) PeerPicker
 )
@@ -3,11 +3,26 @@ package singleflight
 
 import "sync"
 
-// doCall is an in-flight or completed call to Do func.
+// Result holds the result of Do/DoChan for a call to fn, and whether it
+// was shared with other callers.
+type Result struct {
+	Val    any
+	Err    error
+	Shared bool
+}
+
+// doCall is an in-flight or completed call to Do/DoChan.
 type doCall struct {
-	wg  sync.WaitGroup
+	wg sync.WaitGroup
+
 	val any
 	err error
+
+	// dups counts callers, beyond the first, sharing this call's result.
+	dups int
+
+	// chans delivers the result to every DoChan caller once fn returns.
+	chans []chan<- Result
 }
 
 // Group represents a class of work and forms a namespace in which units
@@ -22,24 +37,62 @@ type Group struct {
 // duplicate request comes in, the duplicate caller waits for the original
 // to complete and receives the same results.
 func (g *Group) Do(key string, fn func() (any, error)) (any, error) {
+	res := <-g.DoChan(key, fn)
+	return res.Val, res.Err
+}
+
+// DoChan is like Do but returns a channel that will receive the result
+// when fn completes. Unlike Do, a caller can abandon waiting on the
+// channel - e.g. when its context is canceled - without affecting fn or
+// any other caller sharing the same in-flight call; fn always runs to
+// completion in its own goroutine, and the leader keeps running even
+// if every waiter, including the one that started it, stops waiting.
+func (g *Group) DoChan(key string, fn func() (any, error)) <-chan Result {
+	ch := make(chan Result, 1)
+
 	g.mu.Lock()
 	if g.callMap == nil {
 		g.callMap = make(map[string]*doCall)
 	}
 	if call, ok := g.callMap[key]; ok {
+		call.dups++
+		call.chans = append(call.chans, ch)
 		g.mu.Unlock()
-		call.wg.Wait()
-		return call.val, call.err
+		return ch
 	}
-	call := new(doCall)
+	call := &doCall{chans: []chan<- Result{ch}}
 	call.wg.Add(1)
 	g.callMap[key] = call
 	g.mu.Unlock()
+
+	go g.doCall(key, call, fn)
+	return ch
+}
+
+// doCall runs fn for the leader of an in-flight call and fans the
+// result out to every waiter, including ones that joined after fn
+// started.
+func (g *Group) doCall(key string, call *doCall, fn func() (any, error)) {
 	call.val, call.err = fn()
 	call.wg.Done()
 
+	g.mu.Lock()
+	if g.callMap[key] == call {
+		delete(g.callMap, key)
+	}
+	g.mu.Unlock()
+
+	for _, ch := range call.chans {
+		ch <- Result{Val: call.val, Err: call.err, Shared: call.dups > 0}
+	}
+}
+
+// Forget tells the Group to forget about a key. A future call for that
+// key will execute fn again rather than waiting on a call already in
+// flight, and won't be handed a cached error from a call that just
+// completed.
+func (g *Group) Forget(key string) {
 	g.mu.Lock()
 	delete(g.callMap, key)
 	g.mu.Unlock()
-	return call.val, call.err
 }
@@ -0,0 +1,83 @@
+package singleflight
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo(t *testing.T) {
+	var g Group
+	val, err := g.Do("key", func() (any, error) {
+		return "bar", nil
+	})
+	require.NoError(t, err, "TestDo: Do returned unexpected error")
+	require.Equalf(t, "bar", val, "TestDo: got=%v, want=%v", val, "bar")
+}
+
+func TestDoDedups(t *testing.T) {
+	var g Group
+	var calls int32
+	var wg sync.WaitGroup
+
+	const n = 10
+	results := make([]any, n)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			val, _ := g.Do("key", func() (any, error) {
+				// The sleep holds the leader's call open long enough
+				// for the other goroutines to be scheduled and join it,
+				// instead of racing in as new leaders after it returns
+				// and self-deletes from callMap.
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(10 * time.Millisecond)
+				return "bar", nil
+			})
+			results[i] = val
+		}(i)
+	}
+	wg.Wait()
+
+	require.Equalf(t, int32(1), calls, "TestDoDedups: fn called %d times, want=1", calls)
+	for _, val := range results {
+		require.Equalf(t, "bar", val, "TestDoDedups: got=%v, want=%v", val, "bar")
+	}
+}
+
+func TestForget(t *testing.T) {
+	var g Group
+	g.Forget("key") // forgetting an unknown key is a no-op
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	firstDone := make(chan Result, 1)
+
+	go func() {
+		firstDone <- <-g.DoChan("key", func() (any, error) {
+			close(started)
+			<-release
+			return "first", nil
+		})
+	}()
+	<-started
+
+	// Forgetting the in-flight call means the next Do for the same key
+	// must not be coalesced with it, even though the first call hasn't
+	// finished yet.
+	g.Forget("key")
+
+	val, err := g.Do("key", func() (any, error) {
+		return "second", nil
+	})
+	require.NoError(t, err, "TestForget: Do returned unexpected error")
+	require.Equalf(t, "second", val, "TestForget: got=%v, want=%v", val, "second")
+
+	close(release)
+	first := <-firstDone
+	require.Equalf(t, "first", first.Val, "TestForget: first call's leader should still run to completion")
+}
@@ -2,6 +2,7 @@ package p2pcache
 
 import (
 	"errors"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -24,6 +25,25 @@ type Sink interface {
 	view() (ByteView, error)
 }
 
+// SinkWithExpire is implemented by Sinks that can also record an
+// absolute expiration time alongside the value, letting a Getter
+// declare freshness bounds so the cached entry can self-invalidate via
+// TTL instead of requiring a distributed Remove.
+type SinkWithExpire interface {
+	Sink
+
+	// SetBytesWithExpire sets the value to the contents of b, expiring
+	// it at expireAt. The zero Time means no expiration.
+	SetBytesWithExpire(b []byte, expireAt time.Time) error
+}
+
+// expireGetter is satisfied by Sinks whose received value carries an
+// expiration recorded via SetBytesWithExpire. Group uses it internally
+// to populate the cache with the Getter's declared TTL honored.
+type expireGetter interface {
+	expireAt() time.Time
+}
+
 // cloneBytes deep copies b and returns the copy.
 func cloneBytes(b []byte) []byte {
 	c := make([]byte, len(b))
@@ -47,9 +67,37 @@ func setSinkView(sink Sink, v ByteView) error {
 	return sink.SetString(v.s)
 }
 
+// setSinkViewWithExpire is like setSinkView, but also records expireAt on
+// sink if it implements SinkWithExpire, so a cache hit's TTL survives
+// being re-served (e.g. by ServeHTTP to a peer whose hotCache needs it).
+func setSinkViewWithExpire(sink Sink, v ByteView, expireAt time.Time) error {
+	esink, ok := sink.(SinkWithExpire)
+	if !ok {
+		return setSinkView(sink, v)
+	}
+	if v.b != nil {
+		return esink.SetBytesWithExpire(v.b, expireAt)
+	}
+	return esink.SetBytesWithExpire([]byte(v.s), expireAt)
+}
+
+// Expiry returns the absolute expiration time dest recorded during the
+// preceding Get call, and whether it declared one at all. It's false if
+// dest doesn't support expiration (see SinkWithExpire) or the cached
+// value never expires.
+func Expiry(dest Sink) (time.Time, bool) {
+	eg, ok := dest.(expireGetter)
+	if !ok {
+		return time.Time{}, false
+	}
+	expireAt := eg.expireAt()
+	return expireAt, !expireAt.IsZero()
+}
+
 type stringSink struct {
 	str *string
 	v   ByteView
+	exp time.Time
 }
 
 // StringSink returns a Sink that populates the provided string pointer.
@@ -78,12 +126,25 @@ func (sk *stringSink) SetBytes(b []byte) error {
 	return sk.SetString(string(b))
 }
 
+func (sk *stringSink) SetBytesWithExpire(b []byte, expireAt time.Time) error {
+	if err := sk.SetBytes(b); err != nil {
+		return err
+	}
+	sk.exp = expireAt
+	return nil
+}
+
+func (sk *stringSink) expireAt() time.Time {
+	return sk.exp
+}
+
 func (sk *stringSink) view() (ByteView, error) {
 	return sk.v, nil
 }
 
 type byteViewSink struct {
 	dst *ByteView
+	exp time.Time
 }
 
 // ByteViewSink returns a Sink that populates a ByteView.
@@ -117,6 +178,18 @@ func (sk *byteViewSink) SetBytes(b []byte) error {
 	return nil
 }
 
+func (sk *byteViewSink) SetBytesWithExpire(b []byte, expireAt time.Time) error {
+	if err := sk.SetBytes(b); err != nil {
+		return err
+	}
+	sk.exp = expireAt
+	return nil
+}
+
+func (sk *byteViewSink) expireAt() time.Time {
+	return sk.exp
+}
+
 func (sk *byteViewSink) SetString(str string) error {
 	*sk.dst = ByteView{s: str}
 	return nil
@@ -126,7 +199,8 @@ type protoSink struct {
 	dst proto.Message // authoritative value
 	typ string
 
-	v ByteView // encoded
+	v   ByteView // encoded
+	exp time.Time
 }
 
 // ProtoSink returns a Sink that unmarshals binary proto values into m.
@@ -164,6 +238,18 @@ func (sk *protoSink) SetBytes(b []byte) error {
 	return nil
 }
 
+func (sk *protoSink) SetBytesWithExpire(b []byte, expireAt time.Time) error {
+	if err := sk.SetBytes(b); err != nil {
+		return err
+	}
+	sk.exp = expireAt
+	return nil
+}
+
+func (sk *protoSink) expireAt() time.Time {
+	return sk.exp
+}
+
 func (sk *protoSink) SetString(str string) error {
 	b := []byte(str)
 	err := proto.Unmarshal(b, sk.dst)
@@ -178,6 +264,7 @@ func (sk *protoSink) SetString(str string) error {
 type allocBytesSink struct {
 	dst *[]byte
 	v   ByteView
+	exp time.Time
 }
 
 // AllocatingByteSliceSink returns a Sink that allocates a byte slice
@@ -213,6 +300,18 @@ func (sk *allocBytesSink) SetBytes(b []byte) error {
 	return sk.setBytesOwned(cloneBytes(b))
 }
 
+func (sk *allocBytesSink) SetBytesWithExpire(b []byte, expireAt time.Time) error {
+	if err := sk.SetBytes(b); err != nil {
+		return err
+	}
+	sk.exp = expireAt
+	return nil
+}
+
+func (sk *allocBytesSink) expireAt() time.Time {
+	return sk.exp
+}
+
 func (sk *allocBytesSink) setBytesOwned(b []byte) error {
 	if sk.dst == nil {
 		return errors.New("nil AllocatingByteSliceSink *[]byte dst")
@@ -236,6 +335,7 @@ func (sk *allocBytesSink) SetString(str string) error {
 type truncBytesSink struct {
 	dst *[]byte
 	v   ByteView
+	exp time.Time
 }
 
 // TruncatingByteSliceSink returns a Sink that writes up to len(*dst) bytes
@@ -262,6 +362,18 @@ func (sk *truncBytesSink) SetBytes(b []byte) error {
 	return sk.setBytesOwned(cloneBytes(b))
 }
 
+func (sk *truncBytesSink) SetBytesWithExpire(b []byte, expireAt time.Time) error {
+	if err := sk.SetBytes(b); err != nil {
+		return err
+	}
+	sk.exp = expireAt
+	return nil
+}
+
+func (sk *truncBytesSink) expireAt() time.Time {
+	return sk.exp
+}
+
 func (sk *truncBytesSink) setBytesOwned(b []byte) error {
 	if sk.dst == nil {
 		return errors.New("nil TruncatingByteSliceSink *[]byte dst")